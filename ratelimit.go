@@ -0,0 +1,143 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// KeyFunc extracts the key a request is rate-limited by, e.g. the client
+// IP, a header value, or an authenticated user ID.
+type KeyFunc func(*Context) string
+
+// DefaultKeyFunc keys requests by client IP.
+func DefaultKeyFunc(c *Context) string {
+	return c.ClientIP()
+}
+
+// Limiter decides whether a request against bp may proceed and consume
+// cost tokens. remaining and resetAfter are reported back to the caller
+// so they can be surfaced as X-RateLimit-* response headers.
+type Limiter interface {
+	Allow(c *Context, bp *RateLimitBlueprint, cost int32) (allowed bool, remaining int32, resetAfter time.Duration)
+}
+
+// RateLimitStore holds token bucket state for a tag/key pair. It is an
+// interface so a Redis-backed store can be plugged in to share limits
+// across instances; MemoryStore is the built-in, single-process default.
+type RateLimitStore interface {
+	Take(bp *RateLimitBlueprint, key string, cost int32) (allowed bool, remaining int32, resetAfter time.Duration)
+}
+
+type bucket struct {
+	tokens     int32
+	lastRefill time.Time
+}
+
+// MemoryStore is an in-process RateLimitStore. Buckets are keyed by
+// Bp.Tag plus the caller-supplied key, so routes sharing a blueprint's Tag
+// share its budget.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemoryStore creates an empty in-process RateLimitStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take deducts cost tokens from the bucket identified by bp.Tag and key,
+// refilling it first for any whole periods that have elapsed.
+func (s *MemoryStore) Take(bp *RateLimitBlueprint, key string, cost int32) (bool, int32, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucketKey := bp.Tag + "|" + key
+	now := time.Now()
+	b, ok := s.buckets[bucketKey]
+	if !ok {
+		b = &bucket{tokens: bp.Burst, lastRefill: now}
+		s.buckets[bucketKey] = b
+	}
+
+	period := time.Duration(bp.Period) * time.Millisecond
+	if period > 0 {
+		if elapsed := now.Sub(b.lastRefill); elapsed >= period {
+			refills := int64(elapsed / period)
+			total := int64(b.tokens) + refills*int64(bp.Count)
+			if total > int64(bp.Burst) {
+				total = int64(bp.Burst)
+			}
+			b.tokens = int32(total)
+			b.lastRefill = b.lastRefill.Add(time.Duration(refills) * period)
+		}
+	}
+
+	resetAfter := period - now.Sub(b.lastRefill)
+	if resetAfter < 0 {
+		resetAfter = 0
+	}
+
+	if b.tokens < cost {
+		return false, b.tokens, resetAfter
+	}
+	b.tokens -= cost
+	return true, b.tokens, resetAfter
+}
+
+// TokenBucketLimiter is the default Limiter: a token bucket per blueprint
+// Tag/key pair backed by a pluggable RateLimitStore.
+type TokenBucketLimiter struct {
+	Store   RateLimitStore
+	KeyFunc KeyFunc
+}
+
+// NewTokenBucketLimiter builds a TokenBucketLimiter. A nil store defaults
+// to a new MemoryStore; a nil keyFunc defaults to DefaultKeyFunc.
+func NewTokenBucketLimiter(store RateLimitStore, keyFunc KeyFunc) *TokenBucketLimiter {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	if keyFunc == nil {
+		keyFunc = DefaultKeyFunc
+	}
+	return &TokenBucketLimiter{Store: store, KeyFunc: keyFunc}
+}
+
+// Allow implements Limiter.
+func (l *TokenBucketLimiter) Allow(c *Context, bp *RateLimitBlueprint, cost int32) (bool, int32, time.Duration) {
+	return l.Store.Take(bp, l.KeyFunc(c), cost)
+}
+
+// RateLimiter is the Limiter consulted by every route registered with a
+// non-nil *RouteRate. Replace it, e.g. with a Redis-backed Limiter, to
+// share rate-limit state across instances.
+var RateLimiter Limiter = NewTokenBucketLimiter(nil, nil)
+
+// rateLimitMiddleware enforces rld against RateLimiter, setting the usual
+// X-RateLimit-* headers and aborting with 429 and a Retry-After header
+// once the bucket is exhausted.
+func rateLimitMiddleware(rld *RouteRate) HandlerFunc {
+	return func(c *Context) {
+		bp := rld.Bp
+		allowed, remaining, resetAfter := RateLimiter.Allow(c, bp, rld.Cost)
+		resetSeconds := strconv.Itoa(int((resetAfter + time.Second - 1) / time.Second))
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(int(bp.Burst)))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(remaining)))
+		c.Header("X-RateLimit-Reset", resetSeconds)
+
+		if !allowed {
+			c.Header("Retry-After", resetSeconds)
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
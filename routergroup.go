@@ -30,6 +30,7 @@ type (
 
 	IRoutes interface {
 		Use(...HandlerFunc) IRoutes
+		UseFor([]string, ...HandlerFunc) IRoutes
 
 		Handle(*RouteRate, string, string, ...HandlerFunc) IRoutes
 		Any(*RouteRate, string, ...HandlerFunc) IRoutes
@@ -40,6 +41,8 @@ type (
 		PUT(*RouteRate, string, ...HandlerFunc) IRoutes
 		OPTIONS(*RouteRate, string, ...HandlerFunc) IRoutes
 		HEAD(*RouteRate, string, ...HandlerFunc) IRoutes
+		CONNECT(*RouteRate, string, ...HandlerFunc) IRoutes
+		TRACE(*RouteRate, string, ...HandlerFunc) IRoutes
 
 		StaticFile(*RouteRate, string, string) IRoutes
 		Static(*RouteRate, string, string) IRoutes
@@ -49,10 +52,11 @@ type (
 	// RouterGroup is used internally to configure router, a RouterGroup is associated with a prefix
 	// and an array of handlers (middleware)
 	RouterGroup struct {
-		Handlers HandlersChain
-		basePath string
-		engine   *Engine
-		root     bool
+		Handlers       HandlersChain
+		methodHandlers map[string]HandlersChain
+		basePath       string
+		engine         *Engine
+		root           bool
 	}
 )
 
@@ -64,22 +68,57 @@ func (group *RouterGroup) Use(middleware ...HandlerFunc) IRoutes {
 	return group.returnObj()
 }
 
+// UseFor adds middleware that only runs for routes registered under one of
+// the given HTTP methods, e.g. a CSRF check scoped to the state-changing
+// verbs (POST/PUT/PATCH/DELETE) without needing a sub-group. It is merged
+// with the group's global middleware (see Use) when a route is registered.
+func (group *RouterGroup) UseFor(methods []string, middleware ...HandlerFunc) IRoutes {
+	if group.methodHandlers == nil {
+		group.methodHandlers = make(map[string]HandlersChain)
+	}
+	for _, method := range methods {
+		group.methodHandlers[method] = append(group.methodHandlers[method], middleware...)
+	}
+	return group.returnObj()
+}
+
 // Group creates a new router group. You should add all the routes that have common middlwares or the same path prefix.
 // For example, all the routes that use a common middlware for authorization could be grouped.
 func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup {
 	return &RouterGroup{
-		Handlers: group.combineHandlers(handlers),
-		basePath: group.calculateAbsolutePath(relativePath),
-		engine:   group.engine,
+		Handlers:       group.combineHandlers(handlers),
+		methodHandlers: group.copyMethodHandlers(),
+		basePath:       group.calculateAbsolutePath(relativePath),
+		engine:         group.engine,
 	}
 }
 
+func (group *RouterGroup) copyMethodHandlers() map[string]HandlersChain {
+	if len(group.methodHandlers) == 0 {
+		return nil
+	}
+	copied := make(map[string]HandlersChain, len(group.methodHandlers))
+	for method, handlers := range group.methodHandlers {
+		copied[method] = append(HandlersChain(nil), handlers...)
+	}
+	return copied
+}
+
 func (group *RouterGroup) BasePath() string {
 	return group.basePath
 }
 
 func (group *RouterGroup) handle(rld *RouteRate, httpMethod, relativePath string, handlers HandlersChain) IRoutes {
 	absolutePath := group.calculateAbsolutePath(relativePath)
+	if rld != nil {
+		handlers = append(HandlersChain{rateLimitMiddleware(rld)}, handlers...)
+	}
+	if scoped := group.methodHandlers[httpMethod]; len(scoped) > 0 {
+		merged := make(HandlersChain, 0, len(scoped)+len(handlers))
+		merged = append(merged, scoped...)
+		merged = append(merged, handlers...)
+		handlers = merged
+	}
 	handlers = group.combineHandlers(handlers)
 	group.engine.addRoute(rld, httpMethod, absolutePath, handlers)
 	return group.returnObj()
@@ -137,6 +176,16 @@ func (group *RouterGroup) HEAD(rld *RouteRate, relativePath string, handlers ...
 	return group.handle(rld, "HEAD", relativePath, handlers)
 }
 
+// CONNECT is a shortcut for router.Handle("CONNECT", path, handle)
+func (group *RouterGroup) CONNECT(rld *RouteRate, relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group.handle(rld, "CONNECT", relativePath, handlers)
+}
+
+// TRACE is a shortcut for router.Handle("TRACE", path, handle)
+func (group *RouterGroup) TRACE(rld *RouteRate, relativePath string, handlers ...HandlerFunc) IRoutes {
+	return group.handle(rld, "TRACE", relativePath, handlers)
+}
+
 // Any registers a route that matches all the HTTP methods.
 // GET, POST, PUT, PATCH, HEAD, OPTIONS, DELETE, CONNECT, TRACE
 func (group *RouterGroup) Any(rld *RouteRate, relativePath string, handlers ...HandlerFunc) IRoutes {